@@ -0,0 +1,159 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// idURL is the address of Twitch's OAuth2 identity service. It's a var
+// rather than a const so tests can point it at an httptest server.
+var idURL = "https://id.twitch.tv/oauth2"
+
+// GetAuthorizedUser resolves the client's bearer token to the Twitch user it
+// authenticates as.
+func (client Client) GetAuthorizedUser() (*User, error) {
+	return client.GetAuthorizedUserContext(context.Background())
+}
+
+// GetAuthorizedUserContext resolves the client's bearer token to the Twitch
+// user it authenticates as.
+func (client Client) GetAuthorizedUserContext(ctx context.Context) (*User, error) {
+	return client.GetCurrentUserContext(ctx)
+}
+
+// TokenInfo describes the result of validating a bearer token against
+// Twitch's /oauth2/validate endpoint.
+type TokenInfo struct {
+	ClientID  string   `json:"client_id"`
+	Login     string   `json:"login"`
+	UserID    string   `json:"user_id"`
+	Scopes    []string `json:"scopes"`
+	ExpiresIn int      `json:"expires_in"`
+}
+
+// ValidateToken validates the client's bearer token against Twitch and
+// returns its scopes, expiry, and owning client ID.
+func (client Client) ValidateToken() (*TokenInfo, error) {
+	return client.ValidateTokenContext(context.Background())
+}
+
+// ValidateTokenContext validates the client's bearer token against Twitch
+// and returns its scopes, expiry, and owning client ID.
+func (client Client) ValidateTokenContext(ctx context.Context) (*TokenInfo, error) {
+	bearer := client.currentBearer()
+	if len(bearer) < 1 {
+		return nil, ErrTokenNotSet
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, idURL+"/validate", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "OAuth "+bearer)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("graphql: token validation failed with status %d", resp.StatusCode)
+	}
+
+	info := &TokenInfo{}
+	if err := json.NewDecoder(resp.Body).Decode(info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// TokenSource supplies a bearer token, refreshing it as needed. Token is
+// called by the client's transport whenever a request fails with HTTP 401,
+// and its return value replaces the client's bearer.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// RefreshingTokenSource is a TokenSource that refreshes an OAuth token using
+// the refresh_token grant every time Token is called.
+type RefreshingTokenSource struct {
+	clientID     string
+	clientSecret string
+
+	mu           sync.Mutex
+	refreshToken string
+	accessToken  string
+}
+
+// NewRefreshingTokenSource returns a TokenSource that exchanges
+// refreshToken for new access tokens via Twitch's refresh_token grant.
+func NewRefreshingTokenSource(clientID, clientSecret, refreshToken string) *RefreshingTokenSource {
+	return &RefreshingTokenSource{clientID: clientID, clientSecret: clientSecret, refreshToken: refreshToken}
+}
+
+// Token exchanges the stored refresh token for a new access token.
+func (ts *RefreshingTokenSource) Token(ctx context.Context) (string, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {ts.refreshToken},
+		"client_id":     {ts.clientID},
+		"client_secret": {ts.clientSecret},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, idURL+"/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("graphql: token refresh failed with status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", err
+	}
+
+	ts.accessToken = body.AccessToken
+	if len(body.RefreshToken) > 0 {
+		ts.refreshToken = body.RefreshToken
+	}
+	return ts.accessToken, nil
+}
+
+// SetTokenSource configures the client to automatically refresh its bearer
+// token via ts whenever a request fails with HTTP 401.
+func (client *Client) SetTokenSource(ts TokenSource) {
+	client.tokenSource = ts
+}
+
+// setBearer sets the client's bearer token under its mutex, safe for use
+// from a token refresh running concurrently with in-flight requests.
+func (client *Client) setBearer(token string) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	client.bearer = token
+}
+
+// currentBearer returns the client's current bearer token under its mutex.
+func (client *Client) currentBearer() string {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	return client.bearer
+}
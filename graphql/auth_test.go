@@ -0,0 +1,130 @@
+package graphql
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func withTestIDServer(t *testing.T, handler http.HandlerFunc) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	original := idURL
+	idURL = server.URL
+	t.Cleanup(func() { idURL = original })
+}
+
+func TestValidateTokenContextErrorsWithoutBearer(t *testing.T) {
+	client := New()
+	if _, err := client.ValidateTokenContext(context.Background()); err != ErrTokenNotSet {
+		t.Fatalf("expected ErrTokenNotSet, got %v", err)
+	}
+}
+
+func TestValidateTokenContextParsesResponse(t *testing.T) {
+	withTestIDServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "OAuth abc123" {
+			t.Errorf("Authorization header = %q, want %q", got, "OAuth abc123")
+		}
+		w.Write([]byte(`{"client_id":"cid","login":"someone","user_id":"1","scopes":["chat:read"],"expires_in":60}`))
+	})
+
+	client := New()
+	client.SetBearer("abc123")
+
+	info, err := client.ValidateTokenContext(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.Login != "someone" || info.ExpiresIn != 60 {
+		t.Fatalf("unexpected TokenInfo: %+v", info)
+	}
+}
+
+func TestRefreshingTokenSourceUpdatesTokens(t *testing.T) {
+	withTestIDServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("unexpected form parse error: %v", err)
+		}
+		if got := r.FormValue("refresh_token"); got != "old-refresh" {
+			t.Errorf("refresh_token = %q, want %q", got, "old-refresh")
+		}
+		w.Write([]byte(`{"access_token":"new-access","refresh_token":"new-refresh"}`))
+	})
+
+	ts := NewRefreshingTokenSource("client-id", "client-secret", "old-refresh")
+	token, err := ts.Token(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token != "new-access" {
+		t.Fatalf("Token() = %q, want %q", token, "new-access")
+	}
+	if ts.refreshToken != "new-refresh" {
+		t.Fatalf("refreshToken = %q, want %q", ts.refreshToken, "new-refresh")
+	}
+}
+
+func TestRefreshingTokenSourceKeepsRefreshTokenWhenOmitted(t *testing.T) {
+	withTestIDServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"access_token":"new-access"}`))
+	})
+
+	ts := NewRefreshingTokenSource("client-id", "client-secret", "old-refresh")
+	if _, err := ts.Token(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ts.refreshToken != "old-refresh" {
+		t.Fatalf("refreshToken = %q, want it to stay %q when the response omits one", ts.refreshToken, "old-refresh")
+	}
+}
+
+func TestHttpTransportRefreshesBearerOnceOn401(t *testing.T) {
+	var attempts int
+	gqlServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if r.Header.Get("Authorization") != "OAuth refreshed-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer gqlServer.Close()
+
+	client := New()
+	client.SetBearer("stale-token")
+	client.SetTokenSource(stubTokenSource{token: "refreshed-token"})
+	transport := httpTransport{client: client, next: http.DefaultTransport}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, gqlServer.URL, nil)
+	if err != nil {
+		t.Fatalf("unexpected error building request: %v", err)
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected final status 200, got %d", resp.StatusCode)
+	}
+	if attempts != 2 {
+		t.Fatalf("expected exactly one retry after refreshing the bearer, got %d attempts", attempts)
+	}
+	if got := client.currentBearer(); got != "refreshed-token" {
+		t.Fatalf("expected client bearer to be updated to %q, got %q", "refreshed-token", got)
+	}
+}
+
+type stubTokenSource struct {
+	token string
+}
+
+func (s stubTokenSource) Token(ctx context.Context) (string, error) {
+	return s.token, nil
+}
@@ -0,0 +1,204 @@
+package graphql
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// maxBatchSize is the largest number of IDs Twitch accepts in a single
+// GetUsersByID/GetChannelsByID-style query.
+const maxBatchSize = 100
+
+// defaultMaxConcurrency is the number of batches run concurrently by the
+// GetAll* helpers when no limit has been configured via SetMaxConcurrency.
+const defaultMaxConcurrency = 5
+
+// SetMaxConcurrency limits how many batches the GetAll* helpers run at once.
+// A value below 1 restores defaultMaxConcurrency.
+func (client *Client) SetMaxConcurrency(n int) {
+	if n < 1 {
+		n = defaultMaxConcurrency
+	}
+	client.maxConcurrency = n
+}
+
+// MultiError aggregates the errors returned by a set of batched requests.
+// A nil *MultiError is never returned; use multiError to build one from a
+// slice of errors that may contain nils.
+type MultiError struct {
+	Errors []error
+}
+
+func (e *MultiError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// multiError returns a *MultiError wrapping the non-nil errors in errs, or
+// nil if errs contains no errors.
+func multiError(errs []error) error {
+	var nonNil []error
+	for _, err := range errs {
+		if err != nil {
+			nonNil = append(nonNil, err)
+		}
+	}
+	if len(nonNil) == 0 {
+		return nil
+	}
+	return &MultiError{Errors: nonNil}
+}
+
+// dedupeOrdered removes duplicate values from ids, preserving the order of
+// their first occurrence.
+func dedupeOrdered(ids []string) []string {
+	seen := make(map[string]struct{}, len(ids))
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		out = append(out, id)
+	}
+	return out
+}
+
+// chunk splits ids into slices of at most size entries each.
+func chunk(ids []string, size int) [][]string {
+	var chunks [][]string
+	for size < len(ids) {
+		chunks = append(chunks, ids[:size])
+		ids = ids[size:]
+	}
+	return append(chunks, ids)
+}
+
+// batchConcurrency returns the client's configured max concurrency, or
+// defaultMaxConcurrency if unset.
+func (client *Client) batchConcurrency() int {
+	if client.maxConcurrency < 1 {
+		return defaultMaxConcurrency
+	}
+	return client.maxConcurrency
+}
+
+// GetAllUsersByID retrieves users from Twitch for an arbitrary number of
+// User IDs, chunking the request into batches of 100 and running up to the
+// client's configured max concurrency at a time. Partial results are
+// returned alongside a *MultiError if any batch fails.
+func (client *Client) GetAllUsersByID(ctx context.Context, ids ...string) ([]User, error) {
+	batches := chunk(dedupeOrdered(ids), maxBatchSize)
+	results := make([][]User, len(batches))
+	errs := make([]error, len(batches))
+
+	sem := make(chan struct{}, client.batchConcurrency())
+	var wg sync.WaitGroup
+	for i, batch := range batches {
+		wg.Add(1)
+		go func(i int, batch []string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i], errs[i] = client.GetUsersByIDContext(ctx, batch...)
+		}(i, batch)
+	}
+	wg.Wait()
+
+	var users []User
+	for _, batch := range results {
+		users = append(users, batch...)
+	}
+	return users, multiError(errs)
+}
+
+// GetAllUsersByLogin retrieves users from Twitch for an arbitrary number of
+// usernames, chunking the request into batches of 100 and running up to the
+// client's configured max concurrency at a time. Partial results are
+// returned alongside a *MultiError if any batch fails.
+func (client *Client) GetAllUsersByLogin(ctx context.Context, logins ...string) ([]User, error) {
+	batches := chunk(dedupeOrdered(logins), maxBatchSize)
+	results := make([][]User, len(batches))
+	errs := make([]error, len(batches))
+
+	sem := make(chan struct{}, client.batchConcurrency())
+	var wg sync.WaitGroup
+	for i, batch := range batches {
+		wg.Add(1)
+		go func(i int, batch []string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i], errs[i] = client.GetUsersByLoginContext(ctx, batch...)
+		}(i, batch)
+	}
+	wg.Wait()
+
+	var users []User
+	for _, batch := range results {
+		users = append(users, batch...)
+	}
+	return users, multiError(errs)
+}
+
+// GetAllChannelsByID retrieves channels from Twitch for an arbitrary number
+// of channel IDs, chunking the request into batches of 100 and running up to
+// the client's configured max concurrency at a time. Partial results are
+// returned alongside a *MultiError if any batch fails.
+func (client *Client) GetAllChannelsByID(ctx context.Context, ids ...string) ([]Channel, error) {
+	batches := chunk(dedupeOrdered(ids), maxBatchSize)
+	results := make([][]Channel, len(batches))
+	errs := make([]error, len(batches))
+
+	sem := make(chan struct{}, client.batchConcurrency())
+	var wg sync.WaitGroup
+	for i, batch := range batches {
+		wg.Add(1)
+		go func(i int, batch []string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i], errs[i] = client.GetChannelsByIDContext(ctx, batch...)
+		}(i, batch)
+	}
+	wg.Wait()
+
+	var channels []Channel
+	for _, batch := range results {
+		channels = append(channels, batch...)
+	}
+	return channels, multiError(errs)
+}
+
+// GetAllChannelsByName retrieves channels from Twitch for an arbitrary
+// number of channel names, chunking the request into batches of 100 and
+// running up to the client's configured max concurrency at a time. Partial
+// results are returned alongside a *MultiError if any batch fails.
+func (client *Client) GetAllChannelsByName(ctx context.Context, names ...string) ([]Channel, error) {
+	batches := chunk(dedupeOrdered(names), maxBatchSize)
+	results := make([][]Channel, len(batches))
+	errs := make([]error, len(batches))
+
+	sem := make(chan struct{}, client.batchConcurrency())
+	var wg sync.WaitGroup
+	for i, batch := range batches {
+		wg.Add(1)
+		go func(i int, batch []string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i], errs[i] = client.GetChannelsByNameContext(ctx, batch...)
+		}(i, batch)
+	}
+	wg.Wait()
+
+	var channels []Channel
+	for _, batch := range results {
+		channels = append(channels, batch...)
+	}
+	return channels, multiError(errs)
+}
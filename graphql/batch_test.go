@@ -0,0 +1,68 @@
+package graphql
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDedupeOrderedPreservesFirstOccurrence(t *testing.T) {
+	got := dedupeOrdered([]string{"a", "b", "a", "c", "b"})
+	want := []string{"a", "b", "c"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("dedupeOrdered() = %v, want %v", got, want)
+	}
+}
+
+func TestChunkSplitsIntoSizedBatches(t *testing.T) {
+	got := chunk([]string{"a", "b", "c", "d", "e"}, 2)
+	want := [][]string{{"a", "b"}, {"c", "d"}, {"e"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("chunk() = %v, want %v", got, want)
+	}
+}
+
+func TestChunkSingleBatchWhenUnderSize(t *testing.T) {
+	got := chunk([]string{"a", "b"}, 100)
+	want := [][]string{{"a", "b"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("chunk() = %v, want %v", got, want)
+	}
+}
+
+func TestMultiErrorNilWhenNoErrors(t *testing.T) {
+	if err := multiError([]error{nil, nil}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+}
+
+func TestMultiErrorAggregatesNonNilErrors(t *testing.T) {
+	err := multiError([]error{nil, errors.New("first"), errors.New("second")})
+	merr, ok := err.(*MultiError)
+	if !ok {
+		t.Fatalf("expected *MultiError, got %T", err)
+	}
+	if len(merr.Errors) != 2 {
+		t.Fatalf("expected 2 aggregated errors, got %d", len(merr.Errors))
+	}
+	if got := merr.Error(); got != "first; second" {
+		t.Fatalf("Error() = %q, want %q", got, "first; second")
+	}
+}
+
+func TestBatchConcurrencyDefaultsWhenUnset(t *testing.T) {
+	client := New()
+	if got := client.batchConcurrency(); got != defaultMaxConcurrency {
+		t.Fatalf("batchConcurrency() = %d, want default %d", got, defaultMaxConcurrency)
+	}
+
+	client.SetMaxConcurrency(3)
+	if got := client.batchConcurrency(); got != 3 {
+		t.Fatalf("batchConcurrency() = %d, want 3", got)
+	}
+
+	client.SetMaxConcurrency(0)
+	if got := client.batchConcurrency(); got != defaultMaxConcurrency {
+		t.Fatalf("batchConcurrency() = %d, want default %d after SetMaxConcurrency(0)", got, defaultMaxConcurrency)
+	}
+}
@@ -0,0 +1,141 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// queryCache is an in-memory TTL cache for read-only GraphQL query results,
+// keyed by the query type and its serialized variables.
+type queryCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	entries    map[string]cacheEntry
+	order      []string
+}
+
+type cacheEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+// SetCache enables an in-memory cache for read queries, with entries expiring
+// after ttl and the cache holding at most maxEntries results at a time.
+func (client *Client) SetCache(ttl time.Duration, maxEntries int) {
+	client.cache = &queryCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]cacheEntry),
+	}
+}
+
+// WithCache is the chainable form of SetCache.
+func (client *Client) WithCache(ttl time.Duration, maxEntries int) *Client {
+	client.SetCache(ttl, maxEntries)
+	return client
+}
+
+// InvalidateCache clears all cached query results.
+func (client *Client) InvalidateCache() {
+	if client.cache == nil {
+		return
+	}
+	client.cache.mu.Lock()
+	defer client.cache.mu.Unlock()
+	client.cache.entries = make(map[string]cacheEntry)
+	client.cache.order = nil
+}
+
+// queryOptions holds the per-call options accepted by CustomQueryContext.
+type queryOptions struct {
+	noCache bool
+}
+
+// QueryOption customizes the behavior of a single query call.
+type QueryOption func(*queryOptions)
+
+// NoCache opts a single query call out of the client's response cache.
+func NoCache() QueryOption {
+	return func(o *queryOptions) { o.noCache = true }
+}
+
+// cacheKey builds a cache key from a query's concrete type and its variables.
+func cacheKey(query interface{}, vars map[string]interface{}) (string, error) {
+	encoded, err := json.Marshal(vars)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%T:%s", query, encoded), nil
+}
+
+func (c *queryCache) get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		c.removeOrder(key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// removeOrder drops key from the eviction order, if present. Callers must
+// hold c.mu.
+func (c *queryCache) removeOrder(key string) {
+	for i, k := range c.order {
+		if k == key {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			return
+		}
+	}
+}
+
+// cachedQuery serves query out of the cache when possible, otherwise runs
+// query against the GraphQL server via run and caches a successful result.
+func (client Client) cachedQuery(query interface{}, vars map[string]interface{}, opts []QueryOption, run func() error) error {
+	var options queryOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if client.cache == nil || options.noCache {
+		return run()
+	}
+
+	key, err := cacheKey(query, vars)
+	if err != nil {
+		return run()
+	}
+	if cached, ok := client.cache.get(key); ok {
+		reflect.ValueOf(query).Elem().Set(reflect.ValueOf(cached))
+		return nil
+	}
+
+	if err := run(); err != nil {
+		return err
+	}
+	client.cache.set(key, reflect.ValueOf(query).Elem().Interface())
+	return nil
+}
+
+func (c *queryCache) set(key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, exists := c.entries[key]; !exists {
+		if c.maxEntries > 0 && len(c.entries) >= c.maxEntries && len(c.order) > 0 {
+			oldest := c.order[0]
+			c.order = c.order[1:]
+			delete(c.entries, oldest)
+		}
+		c.order = append(c.order, key)
+	}
+	c.entries[key] = cacheEntry{value: value, expiresAt: time.Now().Add(c.ttl)}
+}
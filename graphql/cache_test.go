@@ -0,0 +1,113 @@
+package graphql
+
+import (
+	"testing"
+	"time"
+)
+
+type fakeQuery struct {
+	Value string
+}
+
+func TestCachedQueryServesFromCache(t *testing.T) {
+	client := New()
+	client.SetCache(time.Minute, 10)
+
+	calls := 0
+	query := &fakeQuery{}
+	if err := client.cachedQuery(query, nil, nil, func() error {
+		calls++
+		query.Value = "first"
+		return nil
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	second := &fakeQuery{}
+	if err := client.cachedQuery(second, nil, nil, func() error {
+		calls++
+		return nil
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if calls != 1 {
+		t.Fatalf("expected cache hit to skip run, got %d calls", calls)
+	}
+	if second.Value != "first" {
+		t.Fatalf("expected cached value to be copied into result, got %q", second.Value)
+	}
+}
+
+func TestCachedQueryExpiresAfterTTL(t *testing.T) {
+	client := New()
+	client.SetCache(10*time.Millisecond, 10)
+
+	calls := 0
+	run := func() error {
+		calls++
+		return nil
+	}
+
+	client.cachedQuery(&fakeQuery{}, nil, nil, run)
+	time.Sleep(20 * time.Millisecond)
+	client.cachedQuery(&fakeQuery{}, nil, nil, run)
+
+	if calls != 2 {
+		t.Fatalf("expected cache entry to expire and re-run, got %d calls", calls)
+	}
+}
+
+func TestCachedQueryNoCacheOptionBypassesCache(t *testing.T) {
+	client := New()
+	client.SetCache(time.Minute, 10)
+
+	calls := 0
+	run := func() error {
+		calls++
+		return nil
+	}
+
+	client.cachedQuery(&fakeQuery{}, nil, nil, run)
+	client.cachedQuery(&fakeQuery{}, nil, []QueryOption{NoCache()}, run)
+
+	if calls != 2 {
+		t.Fatalf("expected NoCache option to bypass the cache, got %d calls", calls)
+	}
+}
+
+func TestQueryCacheEvictsOldestOnceFull(t *testing.T) {
+	cache := &queryCache{ttl: time.Minute, maxEntries: 2, entries: make(map[string]cacheEntry)}
+	cache.set("a", 1)
+	cache.set("b", 2)
+	cache.set("c", 3)
+
+	if _, ok := cache.get("a"); ok {
+		t.Fatalf("expected oldest entry to be evicted")
+	}
+	if _, ok := cache.get("b"); !ok {
+		t.Fatalf("expected entry b to still be present")
+	}
+	if _, ok := cache.get("c"); !ok {
+		t.Fatalf("expected entry c to still be present")
+	}
+}
+
+func TestInvalidateCacheClearsEntries(t *testing.T) {
+	client := New()
+	client.SetCache(time.Minute, 10)
+
+	calls := 0
+	run := func() error {
+		calls++
+		return nil
+	}
+
+	client.cachedQuery(&fakeQuery{}, nil, nil, run)
+	client.InvalidateCache()
+	client.cachedQuery(&fakeQuery{}, nil, nil, run)
+
+	if calls != 2 {
+		t.Fatalf("expected InvalidateCache to force a re-run, got %d calls", calls)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sync"
 
 	"github.com/Adeithe/go-twitch/api"
 	"github.com/shurcooL/graphql"
@@ -13,105 +14,182 @@ import (
 type Client struct {
 	ID     string
 	bearer string
+	mu     *sync.Mutex
 
-	graphql *graphql.Client
+	graphql          *graphql.Client
+	retry            retryPolicy
+	cache            *queryCache
+	maxConcurrency   int
+	tokenSource      TokenSource
+	persistedQueries *persistedQueryRegistry
 }
 
-// URL is the address for the GraphQL server
-const URL = "https://gql.twitch.tv/gql"
+// URL is the address for the GraphQL server. It's a var rather than a const
+// so tests can point it at an httptest server.
+var URL = "https://gql.twitch.tv/gql"
 
 // New Twitch GraphQL Client
 //
 // This uses the official Twitch client by default and therefore should be used sparingly or not at all.
 func New() (client *Client) {
-	client = &Client{ID: api.Official.ID}
+	client = &Client{ID: api.Official.ID, mu: new(sync.Mutex), persistedQueries: newPersistedQueryRegistry()}
 	client.graphql = graphql.NewClient(URL, &http.Client{Transport: httpTransport{client, http.DefaultTransport}})
 	return
 }
 
 // SetBearer sets the token sent with GraphQL requests
 func (client *Client) SetBearer(token string) {
-	client.bearer = token
+	client.setBearer(token)
 }
 
 // CustomQuery executes a query on the GraphQL server
 //
 // See: https://github.com/shurcooL/graphql
-func (client Client) CustomQuery(query interface{}, vars map[string]interface{}) error {
-	return client.graphql.Query(context.Background(), query, vars)
+func (client Client) CustomQuery(query interface{}, vars map[string]interface{}, opts ...QueryOption) error {
+	return client.CustomQueryContext(context.Background(), query, vars, opts...)
+}
+
+// CustomQueryContext executes a query on the GraphQL server using the provided context
+//
+// Results are served from the client's response cache when one is configured
+// via SetCache, unless the NoCache option is passed.
+//
+// See: https://github.com/shurcooL/graphql
+func (client Client) CustomQueryContext(ctx context.Context, query interface{}, vars map[string]interface{}, opts ...QueryOption) error {
+	return client.cachedQuery(query, vars, opts, func() error {
+		return client.graphql.Query(ctx, query, vars)
+	})
 }
 
 // CustomMutation executes a mutation on the GraphQL server
 //
 // See: https://github.com/shurcooL/graphql
 func (client Client) CustomMutation(mutation interface{}, vars map[string]interface{}) error {
-	return client.graphql.Mutate(context.Background(), mutation, vars)
+	return client.CustomMutationContext(context.Background(), mutation, vars)
+}
+
+// CustomMutationContext executes a mutation on the GraphQL server using the provided context
+//
+// Mutations are never cached, and a successful mutation invalidates the
+// client's response cache since it may have changed data backing cached
+// query results.
+//
+// See: https://github.com/shurcooL/graphql
+func (client Client) CustomMutationContext(ctx context.Context, mutation interface{}, vars map[string]interface{}) error {
+	err := client.graphql.Mutate(ctx, mutation, vars)
+	if err == nil {
+		client.InvalidateCache()
+	}
+	return err
 }
 
 // IsUsernameAvailable returns true if the provided username is not taken on Twitch
 func (client *Client) IsUsernameAvailable(username string) (bool, error) {
+	return client.IsUsernameAvailableContext(context.Background(), username)
+}
+
+// IsUsernameAvailableContext returns true if the provided username is not taken on Twitch
+func (client *Client) IsUsernameAvailableContext(ctx context.Context, username string, opts ...QueryOption) (bool, error) {
 	query := GQLUsernameAvailabilityQuery{}
 	vars := map[string]interface{}{"username": graphql.String(username)}
-	err := client.CustomQuery(&query, vars)
+	err := client.CustomQueryContext(ctx, &query, vars, opts...)
 	return query.IsAvailable, err
 }
 
 // GetCurrentUser retrieves the current user based on the clients authentication token
 func (client Client) GetCurrentUser() (*User, error) {
-	if len(client.bearer) < 1 {
+	return client.GetCurrentUserContext(context.Background())
+}
+
+// GetCurrentUserContext retrieves the current user based on the clients authentication token
+//
+// This always bypasses the response cache: the result depends on which
+// bearer token is set at call time, and the cache has no way to key on that.
+func (client Client) GetCurrentUserContext(ctx context.Context) (*User, error) {
+	if len(client.currentBearer()) < 1 {
 		return nil, ErrTokenNotSet
 	}
 	query := GQLCurrentUserQuery{}
-	err := client.CustomQuery(&query, nil)
+	err := client.CustomQueryContext(ctx, &query, nil, NoCache())
 	return query.Data, err
 }
 
 // GetUsersByID retrieves an array of users from Twitch based on their User IDs
 func (client Client) GetUsersByID(ids ...string) ([]User, error) {
+	return client.GetUsersByIDContext(context.Background(), ids...)
+}
+
+// GetUsersByIDContext retrieves an array of users from Twitch based on their User IDs
+//
+// ids is variadic, so this method (like GetUsersByLoginContext,
+// GetChannelsByIDContext and GetChannelsByNameContext) cannot also accept
+// trailing QueryOptions; use CustomQueryContext directly if you need
+// per-call cache control here.
+func (client Client) GetUsersByIDContext(ctx context.Context, ids ...string) ([]User, error) {
 	if len(ids) > 100 {
 		return []User{}, ErrTooManyArguments
 	}
 	query := GQLUserIDsQuery{}
 	vars := map[string]interface{}{"ids": toIDs(ids...)}
-	err := client.CustomQuery(&query, vars)
+	err := client.CustomQueryContext(ctx, &query, vars)
 	return query.Data, err
 }
 
 // GetUsersByLogin retrieves an array of users from Twitch based on their usernames
 func (client Client) GetUsersByLogin(logins ...string) ([]User, error) {
+	return client.GetUsersByLoginContext(context.Background(), logins...)
+}
+
+// GetUsersByLoginContext retrieves an array of users from Twitch based on their usernames
+func (client Client) GetUsersByLoginContext(ctx context.Context, logins ...string) ([]User, error) {
 	if len(logins) > 100 {
 		return []User{}, ErrTooManyArguments
 	}
 	query := GQLUserLoginsQuery{}
 	vars := map[string]interface{}{"logins": toStrings(logins...)}
-	err := client.CustomQuery(&query, vars)
+	err := client.CustomQueryContext(ctx, &query, vars)
 	return query.Data, err
 }
 
 // GetChannelsByID retrieves an array of channels from Twitch based on their IDs
 func (client Client) GetChannelsByID(ids ...string) ([]Channel, error) {
+	return client.GetChannelsByIDContext(context.Background(), ids...)
+}
+
+// GetChannelsByIDContext retrieves an array of channels from Twitch based on their IDs
+func (client Client) GetChannelsByIDContext(ctx context.Context, ids ...string) ([]Channel, error) {
 	if len(ids) > 100 {
 		return []Channel{}, ErrTooManyArguments
 	}
 	query := GQLChannelIDsQuery{}
 	vars := map[string]interface{}{"ids": toIDs(ids...)}
-	err := client.CustomQuery(&query, vars)
+	err := client.CustomQueryContext(ctx, &query, vars)
 	return query.Data, err
 }
 
 // GetChannelsByName retrieves an array of channels from Twitch based on their names
 func (client Client) GetChannelsByName(names ...string) ([]Channel, error) {
+	return client.GetChannelsByNameContext(context.Background(), names...)
+}
+
+// GetChannelsByNameContext retrieves an array of channels from Twitch based on their names
+func (client Client) GetChannelsByNameContext(ctx context.Context, names ...string) ([]Channel, error) {
 	if len(names) > 100 {
 		return []Channel{}, ErrTooManyArguments
 	}
 	query := GQLChannelNamesQuery{}
 	vars := map[string]interface{}{"names": toStrings(names...)}
-	err := client.CustomQuery(&query, vars)
+	err := client.CustomQueryContext(ctx, &query, vars)
 	return query.Data, err
 }
 
 // GetStreams retrieves data about streams available on Twitch
 func (client Client) GetStreams(opts StreamQueryOpts) (*StreamsQuery, error) {
+	return client.GetStreamsContext(context.Background(), opts)
+}
+
+// GetStreamsContext retrieves data about streams available on Twitch
+func (client Client) GetStreamsContext(ctx context.Context, opts StreamQueryOpts, queryOpts ...QueryOption) (*StreamsQuery, error) {
 	if opts.First < 1 || opts.First > 100 {
 		opts.First = 25
 	}
@@ -121,12 +199,17 @@ func (client Client) GetStreams(opts StreamQueryOpts) (*StreamsQuery, error) {
 		"after":   opts.After,
 		"options": opts.Options,
 	}
-	err := client.CustomQuery(&query, vars)
+	err := client.CustomQueryContext(ctx, &query, vars, queryOpts...)
 	return query.Data, err
 }
 
 // GetVideos retrieves videos on Twitch
 func (client Client) GetVideos(opts VideoQueryOpts) (*VideosQuery, error) {
+	return client.GetVideosContext(context.Background(), opts)
+}
+
+// GetVideosContext retrieves videos on Twitch
+func (client Client) GetVideosContext(ctx context.Context, opts VideoQueryOpts, queryOpts ...QueryOption) (*VideosQuery, error) {
 	if opts.First < 1 || opts.First > 100 {
 		opts.First = 25
 	}
@@ -135,17 +218,27 @@ func (client Client) GetVideos(opts VideoQueryOpts) (*VideosQuery, error) {
 		"first": graphql.Int(opts.First),
 		"after": opts.After,
 	}
-	err := client.CustomQuery(&query, vars)
+	err := client.CustomQueryContext(ctx, &query, vars, queryOpts...)
 	return query.Data, err
 }
 
 // GetVideosByChannel retrieves videos on Twitch based on the provided channel
 func (client Client) GetVideosByChannel(channel Channel, opts VideoQueryOpts) (*VideosQuery, error) {
-	return client.GetVideosByUser(User{ID: channel.ID}, opts)
+	return client.GetVideosByChannelContext(context.Background(), channel, opts)
+}
+
+// GetVideosByChannelContext retrieves videos on Twitch based on the provided channel
+func (client Client) GetVideosByChannelContext(ctx context.Context, channel Channel, opts VideoQueryOpts, queryOpts ...QueryOption) (*VideosQuery, error) {
+	return client.GetVideosByUserContext(ctx, User{ID: channel.ID}, opts, queryOpts...)
 }
 
 // GetVideosByUser retrieves videos on Twitch based on the provided user
 func (client Client) GetVideosByUser(user User, opts VideoQueryOpts) (*VideosQuery, error) {
+	return client.GetVideosByUserContext(context.Background(), user, opts)
+}
+
+// GetVideosByUserContext retrieves videos on Twitch based on the provided user
+func (client Client) GetVideosByUserContext(ctx context.Context, user User, opts VideoQueryOpts, queryOpts ...QueryOption) (*VideosQuery, error) {
 	if opts.First < 1 || opts.First > 100 {
 		opts.First = 25
 	}
@@ -155,7 +248,7 @@ func (client Client) GetVideosByUser(user User, opts VideoQueryOpts) (*VideosQue
 		"first": graphql.Int(opts.First),
 		"after": opts.After,
 	}
-	err := client.CustomQuery(&query, vars)
+	err := client.CustomQueryContext(ctx, &query, vars, queryOpts...)
 	if query.Data == nil {
 		return nil, err
 	}
@@ -164,14 +257,24 @@ func (client Client) GetVideosByUser(user User, opts VideoQueryOpts) (*VideosQue
 
 // GetClipBySlug retrieves data about a clip available on Twitch by its slug
 func (client Client) GetClipBySlug(slug string) (*Clip, error) {
+	return client.GetClipBySlugContext(context.Background(), slug)
+}
+
+// GetClipBySlugContext retrieves data about a clip available on Twitch by its slug
+func (client Client) GetClipBySlugContext(ctx context.Context, slug string, queryOpts ...QueryOption) (*Clip, error) {
 	query := GQLClipQuery{}
 	vars := map[string]interface{}{"slug": slug}
-	err := client.CustomQuery(&query, vars)
+	err := client.CustomQueryContext(ctx, &query, vars, queryOpts...)
 	return query.Data, err
 }
 
 // GetGames retrieves data about games available on Twitch
 func (client Client) GetGames(opts GameQueryOpts) (*GamesQuery, error) {
+	return client.GetGamesContext(context.Background(), opts)
+}
+
+// GetGamesContext retrieves data about games available on Twitch
+func (client Client) GetGamesContext(ctx context.Context, opts GameQueryOpts, queryOpts ...QueryOption) (*GamesQuery, error) {
 	if opts.First < 1 || opts.First > 100 {
 		opts.First = 25
 	}
@@ -181,12 +284,17 @@ func (client Client) GetGames(opts GameQueryOpts) (*GamesQuery, error) {
 		"after":   opts.After,
 		"options": opts.Options,
 	}
-	err := client.CustomQuery(&query, vars)
+	err := client.CustomQueryContext(ctx, &query, vars, queryOpts...)
 	return query.Data, err
 }
 
 // GetFollowersForUser retrieves data about who follows the provided user on Twitch
 func (client Client) GetFollowersForUser(user User, opts FollowQueryOpts) (*FollowersQuery, error) {
+	return client.GetFollowersForUserContext(context.Background(), user, opts)
+}
+
+// GetFollowersForUserContext retrieves data about who follows the provided user on Twitch
+func (client Client) GetFollowersForUserContext(ctx context.Context, user User, opts FollowQueryOpts, queryOpts ...QueryOption) (*FollowersQuery, error) {
 	if user.ID == nil || len(fmt.Sprint(user.ID)) < 1 {
 		return nil, ErrInvalidArgument
 	}
@@ -199,7 +307,7 @@ func (client Client) GetFollowersForUser(user User, opts FollowQueryOpts) (*Foll
 		"first": graphql.Int(opts.First),
 		"after": opts.After,
 	}
-	err := client.CustomQuery(&query, vars)
+	err := client.CustomQueryContext(ctx, &query, vars, queryOpts...)
 	if query.Data == nil {
 		return nil, err
 	}
@@ -208,6 +316,11 @@ func (client Client) GetFollowersForUser(user User, opts FollowQueryOpts) (*Foll
 
 // GetFollowersForChannel retrieves data about who follows the provided channel on Twitch
 func (client Client) GetFollowersForChannel(channel Channel, opts FollowQueryOpts) (*FollowersQuery, error) {
+	return client.GetFollowersForChannelContext(context.Background(), channel, opts)
+}
+
+// GetFollowersForChannelContext retrieves data about who follows the provided channel on Twitch
+func (client Client) GetFollowersForChannelContext(ctx context.Context, channel Channel, opts FollowQueryOpts, queryOpts ...QueryOption) (*FollowersQuery, error) {
 	if channel.ID == nil || len(fmt.Sprint(channel.ID)) < 1 {
 		return nil, ErrInvalidArgument
 	}
@@ -220,7 +333,7 @@ func (client Client) GetFollowersForChannel(channel Channel, opts FollowQueryOpt
 		"first": graphql.Int(opts.First),
 		"after": opts.After,
 	}
-	err := client.CustomQuery(&query, vars)
+	err := client.CustomQueryContext(ctx, &query, vars, queryOpts...)
 	if query.Data == nil {
 		return nil, err
 	}
@@ -229,16 +342,31 @@ func (client Client) GetFollowersForChannel(channel Channel, opts FollowQueryOpt
 
 // GetModsForChannel retrieves data about who is a moderator for the provided channel on Twitch
 func (client Client) GetModsForChannel(channel Channel, opts ModsQueryOpts) (*ModsQuery, error) {
-	return client.GetModsForUser(User{ID: channel.ID}, opts)
+	return client.GetModsForChannelContext(context.Background(), channel, opts)
+}
+
+// GetModsForChannelContext retrieves data about who is a moderator for the provided channel on Twitch
+func (client Client) GetModsForChannelContext(ctx context.Context, channel Channel, opts ModsQueryOpts, queryOpts ...QueryOption) (*ModsQuery, error) {
+	return client.GetModsForUserContext(ctx, User{ID: channel.ID}, opts, queryOpts...)
 }
 
 // GetVIPsForChannel retrieves data about who is a VIP for the provided channel on Twitch
 func (client Client) GetVIPsForChannel(channel Channel, opts VIPsQueryOpts) (*VIPsQuery, error) {
-	return client.GetVIPsForUser(User{ID: channel.ID}, opts)
+	return client.GetVIPsForChannelContext(context.Background(), channel, opts)
+}
+
+// GetVIPsForChannelContext retrieves data about who is a VIP for the provided channel on Twitch
+func (client Client) GetVIPsForChannelContext(ctx context.Context, channel Channel, opts VIPsQueryOpts, queryOpts ...QueryOption) (*VIPsQuery, error) {
+	return client.GetVIPsForUserContext(ctx, User{ID: channel.ID}, opts, queryOpts...)
 }
 
 // GetModsForUser retrieves data about who is a moderator for the provided user on Twitch
 func (client Client) GetModsForUser(user User, opts ModsQueryOpts) (*ModsQuery, error) {
+	return client.GetModsForUserContext(context.Background(), user, opts)
+}
+
+// GetModsForUserContext retrieves data about who is a moderator for the provided user on Twitch
+func (client Client) GetModsForUserContext(ctx context.Context, user User, opts ModsQueryOpts, queryOpts ...QueryOption) (*ModsQuery, error) {
 	if user.ID == nil || len(fmt.Sprint(user.ID)) < 1 {
 		return nil, ErrInvalidArgument
 	}
@@ -251,12 +379,17 @@ func (client Client) GetModsForUser(user User, opts ModsQueryOpts) (*ModsQuery,
 		"first": graphql.Int(opts.First),
 		"after": opts.After,
 	}
-	err := client.CustomQuery(&query, vars)
+	err := client.CustomQueryContext(ctx, &query, vars, queryOpts...)
 	return query.Data.Mods, err
 }
 
 // GetVIPsForUser retrieves data about who is a VIP for the provided user on Twitch
 func (client Client) GetVIPsForUser(user User, opts VIPsQueryOpts) (*VIPsQuery, error) {
+	return client.GetVIPsForUserContext(context.Background(), user, opts)
+}
+
+// GetVIPsForUserContext retrieves data about who is a VIP for the provided user on Twitch
+func (client Client) GetVIPsForUserContext(ctx context.Context, user User, opts VIPsQueryOpts, queryOpts ...QueryOption) (*VIPsQuery, error) {
 	if user.ID == nil || len(fmt.Sprint(user.ID)) < 1 {
 		return nil, ErrInvalidArgument
 	}
@@ -269,6 +402,6 @@ func (client Client) GetVIPsForUser(user User, opts VIPsQueryOpts) (*VIPsQuery,
 		"first": graphql.Int(opts.First),
 		"after": opts.After,
 	}
-	err := client.CustomQuery(&query, vars)
+	err := client.CustomQueryContext(ctx, &query, vars, queryOpts...)
 	return query.Data.VIPs, err
 }
@@ -0,0 +1,65 @@
+package graphql
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shurcooL/graphql"
+)
+
+// slowQuery is a minimal shurcooL/graphql query struct so tests don't
+// depend on any of the real Twitch GQL* query types.
+type slowQuery struct {
+	Data struct {
+		Ok bool
+	}
+}
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*Client, func()) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	client := New()
+	client.graphql = graphql.NewClient(server.URL, http.DefaultClient)
+	return client, server.Close
+}
+
+func TestCustomQueryContextRespectsCancellation(t *testing.T) {
+	unblock := make(chan struct{})
+	client, cleanup := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+	})
+	defer cleanup()
+	defer close(unblock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := client.CustomQueryContext(ctx, &slowQuery{}, nil)
+	if err == nil {
+		t.Fatal("expected an error for an already-canceled context")
+	}
+}
+
+func TestCustomQueryContextRespectsDeadline(t *testing.T) {
+	unblock := make(chan struct{})
+	client, cleanup := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+	})
+	defer cleanup()
+	defer close(unblock)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := client.CustomQueryContext(ctx, &slowQuery{}, nil)
+	if err == nil {
+		t.Fatal("expected a deadline exceeded error")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected the call to return promptly after the deadline, took %v", elapsed)
+	}
+}
@@ -0,0 +1,248 @@
+package graphql
+
+import "context"
+
+// iterator is the shared paginator core behind every exported *Iterator type
+// in this package. It fetches one page at a time via fetch, which is
+// responsible for applying the current cursor to its query and reporting
+// whether another page follows.
+//
+// Iterators are not safe for concurrent use.
+type iterator[T any] struct {
+	fetch func(ctx context.Context, after string) (page []T, hasNextPage bool, cursor string, err error)
+	after string
+
+	page    []T
+	index   int
+	current T
+
+	done bool
+	err  error
+}
+
+func newIterator[T any](after string, fetch func(ctx context.Context, after string) ([]T, bool, string, error)) *iterator[T] {
+	return &iterator[T]{fetch: fetch, after: after}
+}
+
+// Next advances the iterator to the next item, fetching the next page from
+// Twitch if necessary. It returns false once iteration is complete or an
+// error occurs; call Err to distinguish the two.
+func (it *iterator[T]) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+	if it.index < len(it.page) {
+		it.current = it.page[it.index]
+		it.index++
+		return true
+	}
+	if it.done {
+		return false
+	}
+
+	page, hasNextPage, cursor, err := it.fetch(ctx, it.after)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.page = page
+	it.index = 0
+	if len(it.page) == 0 || !hasNextPage || len(cursor) == 0 {
+		it.done = true
+	} else {
+		it.after = cursor
+	}
+
+	if len(it.page) == 0 {
+		return false
+	}
+	it.current = it.page[it.index]
+	it.index++
+	return true
+}
+
+// Value returns the item at the iterator's current position. It is only
+// valid after a call to Next returns true.
+func (it *iterator[T]) Value() T {
+	return it.current
+}
+
+// Err returns the first error encountered while iterating, if any.
+func (it *iterator[T]) Err() error {
+	return it.err
+}
+
+// collect drains it up to limit items. A limit of 0 or less collects every
+// item.
+func collect[T any](ctx context.Context, it *iterator[T], limit int) ([]T, error) {
+	var items []T
+	for it.Next(ctx) {
+		items = append(items, it.Value())
+		if limit > 0 && len(items) >= limit {
+			break
+		}
+	}
+	if err := it.Err(); err != nil {
+		return items, err
+	}
+	return items, nil
+}
+
+// FollowersIterator iterates over the followers of a user, automatically
+// advancing the cursor and fetching the next page on demand.
+type FollowersIterator = iterator[Follower]
+
+// IterateFollowers returns an iterator over the followers of the provided
+// user, starting from opts.After.
+func (client *Client) IterateFollowers(user User, opts FollowQueryOpts) *FollowersIterator {
+	return newIterator(opts.After, func(ctx context.Context, after string) ([]Follower, bool, string, error) {
+		opts.After = after
+		query, err := client.GetFollowersForUserContext(ctx, user, opts)
+		if err != nil {
+			return nil, false, "", err
+		}
+		return query.Followers, query.PageInfo.HasNextPage, query.Cursor, nil
+	})
+}
+
+// AllFollowers collects up to limit followers of the provided user, fetching
+// as many pages as necessary. A limit of 0 or less collects every follower.
+func (client *Client) AllFollowers(ctx context.Context, user User, opts FollowQueryOpts, limit int) ([]Follower, error) {
+	return collect(ctx, client.IterateFollowers(user, opts), limit)
+}
+
+// StreamsIterator iterates over the streams returned by GetStreams,
+// automatically advancing the cursor and fetching the next page on demand.
+type StreamsIterator = iterator[Stream]
+
+// IterateStreams returns an iterator over the streams matching opts,
+// starting from opts.After.
+func (client *Client) IterateStreams(opts StreamQueryOpts) *StreamsIterator {
+	return newIterator(opts.After, func(ctx context.Context, after string) ([]Stream, bool, string, error) {
+		opts.After = after
+		query, err := client.GetStreamsContext(ctx, opts)
+		if err != nil {
+			return nil, false, "", err
+		}
+		return query.Streams, query.PageInfo.HasNextPage, query.Cursor, nil
+	})
+}
+
+// AllStreams collects up to limit streams matching opts, fetching as many
+// pages as necessary. A limit of 0 or less collects every stream.
+func (client *Client) AllStreams(ctx context.Context, opts StreamQueryOpts, limit int) ([]Stream, error) {
+	return collect(ctx, client.IterateStreams(opts), limit)
+}
+
+// VideosIterator iterates over a list of videos, automatically advancing the
+// cursor and fetching the next page on demand.
+type VideosIterator = iterator[Video]
+
+// IterateVideos returns an iterator over the videos matching opts, starting
+// from opts.After.
+func (client *Client) IterateVideos(opts VideoQueryOpts) *VideosIterator {
+	return newIterator(opts.After, func(ctx context.Context, after string) ([]Video, bool, string, error) {
+		opts.After = after
+		query, err := client.GetVideosContext(ctx, opts)
+		if err != nil {
+			return nil, false, "", err
+		}
+		return query.Videos, query.PageInfo.HasNextPage, query.Cursor, nil
+	})
+}
+
+// IterateVideosByUser returns an iterator over the videos uploaded by the
+// provided user, starting from opts.After.
+func (client *Client) IterateVideosByUser(user User, opts VideoQueryOpts) *VideosIterator {
+	return newIterator(opts.After, func(ctx context.Context, after string) ([]Video, bool, string, error) {
+		opts.After = after
+		query, err := client.GetVideosByUserContext(ctx, user, opts)
+		if err != nil {
+			return nil, false, "", err
+		}
+		return query.Videos, query.PageInfo.HasNextPage, query.Cursor, nil
+	})
+}
+
+// AllVideos collects up to limit videos matching opts, fetching as many
+// pages as necessary. A limit of 0 or less collects every video.
+func (client *Client) AllVideos(ctx context.Context, opts VideoQueryOpts, limit int) ([]Video, error) {
+	return collect(ctx, client.IterateVideos(opts), limit)
+}
+
+// AllVideosByUser collects up to limit videos uploaded by the provided user,
+// fetching as many pages as necessary. A limit of 0 or less collects every
+// video.
+func (client *Client) AllVideosByUser(ctx context.Context, user User, opts VideoQueryOpts, limit int) ([]Video, error) {
+	return collect(ctx, client.IterateVideosByUser(user, opts), limit)
+}
+
+// ModsIterator iterates over the moderators of a user, automatically
+// advancing the cursor and fetching the next page on demand.
+type ModsIterator = iterator[Mod]
+
+// IterateMods returns an iterator over the moderators of the provided user,
+// starting from opts.After.
+func (client *Client) IterateMods(user User, opts ModsQueryOpts) *ModsIterator {
+	return newIterator(opts.After, func(ctx context.Context, after string) ([]Mod, bool, string, error) {
+		opts.After = after
+		query, err := client.GetModsForUserContext(ctx, user, opts)
+		if err != nil {
+			return nil, false, "", err
+		}
+		return query.Mods, query.PageInfo.HasNextPage, query.Cursor, nil
+	})
+}
+
+// AllMods collects up to limit moderators of the provided user, fetching as
+// many pages as necessary. A limit of 0 or less collects every moderator.
+func (client *Client) AllMods(ctx context.Context, user User, opts ModsQueryOpts, limit int) ([]Mod, error) {
+	return collect(ctx, client.IterateMods(user, opts), limit)
+}
+
+// VIPsIterator iterates over the VIPs of a user, automatically advancing the
+// cursor and fetching the next page on demand.
+type VIPsIterator = iterator[VIP]
+
+// IterateVIPs returns an iterator over the VIPs of the provided user,
+// starting from opts.After.
+func (client *Client) IterateVIPs(user User, opts VIPsQueryOpts) *VIPsIterator {
+	return newIterator(opts.After, func(ctx context.Context, after string) ([]VIP, bool, string, error) {
+		opts.After = after
+		query, err := client.GetVIPsForUserContext(ctx, user, opts)
+		if err != nil {
+			return nil, false, "", err
+		}
+		return query.VIPs, query.PageInfo.HasNextPage, query.Cursor, nil
+	})
+}
+
+// AllVIPs collects up to limit VIPs of the provided user, fetching as many
+// pages as necessary. A limit of 0 or less collects every VIP.
+func (client *Client) AllVIPs(ctx context.Context, user User, opts VIPsQueryOpts, limit int) ([]VIP, error) {
+	return collect(ctx, client.IterateVIPs(user, opts), limit)
+}
+
+// GamesIterator iterates over the games returned by GetGames, automatically
+// advancing the cursor and fetching the next page on demand.
+type GamesIterator = iterator[Game]
+
+// IterateGames returns an iterator over the games matching opts, starting
+// from opts.After.
+func (client *Client) IterateGames(opts GameQueryOpts) *GamesIterator {
+	return newIterator(opts.After, func(ctx context.Context, after string) ([]Game, bool, string, error) {
+		opts.After = after
+		query, err := client.GetGamesContext(ctx, opts)
+		if err != nil {
+			return nil, false, "", err
+		}
+		return query.Games, query.PageInfo.HasNextPage, query.Cursor, nil
+	})
+}
+
+// AllGames collects up to limit games matching opts, fetching as many pages
+// as necessary. A limit of 0 or less collects every game.
+func (client *Client) AllGames(ctx context.Context, opts GameQueryOpts, limit int) ([]Game, error) {
+	return collect(ctx, client.IterateGames(opts), limit)
+}
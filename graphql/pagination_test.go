@@ -0,0 +1,92 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func pagedFetch(pages [][]string) func(ctx context.Context, after string) ([]string, bool, string, error) {
+	calls := 0
+	return func(ctx context.Context, after string) ([]string, bool, string, error) {
+		if calls >= len(pages) {
+			return nil, false, "", nil
+		}
+		page := pages[calls]
+		calls++
+		hasNext := calls < len(pages)
+		cursor := ""
+		if hasNext {
+			cursor = "cursor"
+		}
+		return page, hasNext, cursor, nil
+	}
+}
+
+func TestIteratorWalksAllPages(t *testing.T) {
+	it := newIterator("", pagedFetch([][]string{{"a", "b"}, {"c"}}))
+
+	var got []string
+	for it.Next(context.Background()) {
+		got = append(got, it.Value())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"a", "b", "c"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestIteratorStopsOnEmptyPage(t *testing.T) {
+	it := newIterator("", pagedFetch([][]string{{}}))
+	if it.Next(context.Background()) {
+		t.Fatal("expected Next to return false on an empty first page")
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestIteratorPropagatesFetchError(t *testing.T) {
+	fetchErr := errors.New("fetch failed")
+	it := newIterator("", func(ctx context.Context, after string) ([]string, bool, string, error) {
+		return nil, false, "", fetchErr
+	})
+
+	if it.Next(context.Background()) {
+		t.Fatal("expected Next to return false on error")
+	}
+	if !errors.Is(it.Err(), fetchErr) {
+		t.Fatalf("Err() = %v, want %v", it.Err(), fetchErr)
+	}
+}
+
+func TestCollectRespectsLimit(t *testing.T) {
+	it := newIterator("", pagedFetch([][]string{{"a", "b"}, {"c", "d"}}))
+	got, err := collect(context.Background(), it, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("expected collect to stop at limit, got %d items: %v", len(got), got)
+	}
+}
+
+func TestCollectZeroLimitCollectsEverything(t *testing.T) {
+	it := newIterator("", pagedFetch([][]string{{"a", "b"}, {"c"}}))
+	got, err := collect(context.Background(), it, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("expected collect with limit 0 to gather every item, got %d: %v", len(got), got)
+	}
+}
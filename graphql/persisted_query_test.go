@@ -0,0 +1,105 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func withTestURL(t *testing.T, url string) {
+	t.Helper()
+	original := URL
+	URL = url
+	t.Cleanup(func() { URL = original })
+}
+
+func TestCustomPersistedQueryContextSendsHashOnly(t *testing.T) {
+	var gotBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		w.Write([]byte(`{"data":{"ok":true}}`))
+	}))
+	defer server.Close()
+
+	withTestURL(t, server.URL)
+	client := New()
+	client.RegisterPersistedQuery("MyQuery", "deadbeef")
+
+	var out struct {
+		OK bool `json:"ok"`
+	}
+	if err := client.CustomPersistedQueryContext(context.Background(), "MyQuery", nil, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !out.OK {
+		t.Fatal("expected decoded result to have OK=true")
+	}
+	if _, hasQuery := gotBody["query"]; hasQuery {
+		t.Fatal("expected a hash-only request, got a full query document")
+	}
+}
+
+func TestCustomPersistedQueryContextFallsBackToDocumentOnNotFound(t *testing.T) {
+	var bodies []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		bodies = append(bodies, body)
+		if len(bodies) == 1 {
+			w.Write([]byte(`{"errors":[{"message":"PersistedQueryNotFound"}]}`))
+			return
+		}
+		w.Write([]byte(`{"data":{"ok":true}}`))
+	}))
+	defer server.Close()
+
+	withTestURL(t, server.URL)
+	client := New()
+	client.RegisterPersistedQuery("MyQuery", "deadbeef")
+	client.RegisterQueryDocument("MyQuery", "query MyQuery { ok }")
+
+	var out struct {
+		OK bool `json:"ok"`
+	}
+	if err := client.CustomPersistedQueryContext(context.Background(), "MyQuery", nil, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !out.OK {
+		t.Fatal("expected decoded result to have OK=true after falling back")
+	}
+	if len(bodies) != 2 {
+		t.Fatalf("expected exactly 2 requests (hash-only then full document), got %d", len(bodies))
+	}
+	if _, hasQuery := bodies[1]["query"]; !hasQuery {
+		t.Fatal("expected the fallback request to include the full query document")
+	}
+}
+
+func TestCustomPersistedQueryContextNotFoundWithoutFallbackDocument(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"errors":[{"message":"PersistedQueryNotFound"}]}`))
+	}))
+	defer server.Close()
+
+	withTestURL(t, server.URL)
+	client := New()
+	client.RegisterPersistedQuery("MyQuery", "deadbeef")
+
+	err := client.CustomPersistedQueryContext(context.Background(), "MyQuery", nil, nil)
+	if err != ErrPersistedQueryNotFound {
+		t.Fatalf("expected ErrPersistedQueryNotFound, got %v", err)
+	}
+}
+
+func TestCustomPersistedQueryContextUnregisteredName(t *testing.T) {
+	client := New()
+	if err := client.CustomPersistedQueryContext(context.Background(), "Unknown", nil, nil); err == nil {
+		t.Fatal("expected an error for an unregistered persisted query name")
+	}
+}
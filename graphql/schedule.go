@@ -0,0 +1,85 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/shurcooL/graphql"
+)
+
+// ScheduleQueryOpts configures a stream schedule lookup
+type ScheduleQueryOpts struct {
+	First int
+	After string
+}
+
+// ScheduleSegment is a single broadcast on a channel's stream schedule
+type ScheduleSegment struct {
+	ID            string
+	StartAt       string
+	EndAt         string
+	Title         string
+	CanceledUntil string
+	IsRecurring   bool
+	Category      Game
+}
+
+// ScheduleVacation describes a vacation window on a channel's stream
+// schedule, during which no segments are expected to air
+type ScheduleVacation struct {
+	StartAt string
+	EndAt   string
+}
+
+// ScheduleQuery is the stream schedule for a channel
+type ScheduleQuery struct {
+	Segments []ScheduleSegment
+	Vacation *ScheduleVacation
+	Cursor   string
+	PageInfo struct {
+		HasNextPage bool
+	}
+}
+
+// GQLScheduleQuery is the top level GraphQL query used by
+// GetScheduleForChannel and GetScheduleForUser
+type GQLScheduleQuery struct {
+	Data *struct {
+		Schedule *ScheduleQuery `graphql:"schedule(first: $first, after: $after)"`
+	} `graphql:"user(id: $id)"`
+}
+
+// GetScheduleForChannel retrieves the stream schedule for the provided
+// channel
+func (client Client) GetScheduleForChannel(channel Channel, opts ScheduleQueryOpts) (*ScheduleQuery, error) {
+	return client.GetScheduleForChannelContext(context.Background(), channel, opts)
+}
+
+// GetScheduleForChannelContext retrieves the stream schedule for the
+// provided channel
+func (client Client) GetScheduleForChannelContext(ctx context.Context, channel Channel, opts ScheduleQueryOpts) (*ScheduleQuery, error) {
+	return client.GetScheduleForUserContext(ctx, User{ID: channel.ID}, opts)
+}
+
+// GetScheduleForUser retrieves the stream schedule for the provided user
+func (client Client) GetScheduleForUser(user User, opts ScheduleQueryOpts) (*ScheduleQuery, error) {
+	return client.GetScheduleForUserContext(context.Background(), user, opts)
+}
+
+// GetScheduleForUserContext retrieves the stream schedule for the provided
+// user
+func (client Client) GetScheduleForUserContext(ctx context.Context, user User, opts ScheduleQueryOpts) (*ScheduleQuery, error) {
+	if opts.First < 1 || opts.First > 100 {
+		opts.First = 25
+	}
+	query := GQLScheduleQuery{}
+	vars := map[string]interface{}{
+		"id":    user.ID,
+		"first": graphql.Int(opts.First),
+		"after": opts.After,
+	}
+	err := client.CustomQueryContext(ctx, &query, vars)
+	if query.Data == nil {
+		return nil, err
+	}
+	return query.Data.Schedule, err
+}
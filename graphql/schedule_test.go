@@ -0,0 +1,64 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func captureVariables(t *testing.T, response string) (*Client, func(), func() map[string]interface{}) {
+	t.Helper()
+	var captured map[string]interface{}
+	client, cleanup := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		captured = body.Variables
+		w.Write([]byte(response))
+	})
+	return client, cleanup, func() map[string]interface{} { return captured }
+}
+
+func TestGetScheduleForUserContextDefaultsOutOfRangeFirst(t *testing.T) {
+	client, cleanup, variables := captureVariables(t, `{"data":{"user":null}}`)
+	defer cleanup()
+
+	schedule, err := client.GetScheduleForUserContext(context.Background(), User{ID: "123"}, ScheduleQueryOpts{First: 0})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if schedule != nil {
+		t.Fatalf("expected a nil schedule when the user isn't found, got %+v", schedule)
+	}
+	if got := variables()["first"]; got != float64(25) {
+		t.Fatalf("first = %v, want 25 after defaulting an out-of-range value", got)
+	}
+}
+
+func TestGetScheduleForUserContextKeepsValidFirst(t *testing.T) {
+	client, cleanup, variables := captureVariables(t, `{"data":{"user":null}}`)
+	defer cleanup()
+
+	if _, err := client.GetScheduleForUserContext(context.Background(), User{ID: "123"}, ScheduleQueryOpts{First: 10}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := variables()["first"]; got != float64(10) {
+		t.Fatalf("first = %v, want 10", got)
+	}
+}
+
+func TestGetScheduleForChannelContextUsesChannelID(t *testing.T) {
+	client, cleanup, variables := captureVariables(t, `{"data":{"user":null}}`)
+	defer cleanup()
+
+	if _, err := client.GetScheduleForChannelContext(context.Background(), Channel{ID: "chan-1"}, ScheduleQueryOpts{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := variables()["id"]; got != "chan-1" {
+		t.Fatalf("id = %v, want %q", got, "chan-1")
+	}
+}
@@ -0,0 +1,128 @@
+package graphql
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// defaultMaxAttempts is the number of attempts made to a request when no retry
+// policy has been configured, i.e. no retries.
+const defaultMaxAttempts = 1
+
+// retryPolicy controls how httpTransport retries transient failures.
+type retryPolicy struct {
+	maxAttempts int
+	baseDelay   time.Duration
+}
+
+var defaultRetryPolicy = retryPolicy{maxAttempts: defaultMaxAttempts}
+
+// SetRetryPolicy configures the client to retry transient GraphQL request
+// failures (HTTP 429 and 5xx responses) up to maxAttempts times, using an
+// exponential backoff starting at baseDelay between attempts.
+//
+// A maxAttempts of 1 (the default) disables retries entirely.
+func (client *Client) SetRetryPolicy(maxAttempts int, baseDelay time.Duration) {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	client.retry = retryPolicy{maxAttempts: maxAttempts, baseDelay: baseDelay}
+}
+
+// httpTransport is the http.RoundTripper used by Client to attach
+// authentication headers to every outgoing GraphQL request.
+type httpTransport struct {
+	client *Client
+	next   http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper, retrying transient failures
+// according to the client's configured retry policy and, when a
+// TokenSource is configured, refreshing the bearer token and
+// retrying once on an HTTP 401.
+func (t httpTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	policy := t.client.retry
+	if policy.maxAttempts < 1 {
+		policy = defaultRetryPolicy
+	}
+
+	refreshedAuth := false
+	var resp *http.Response
+	var err error
+	for attempt := 0; attempt < policy.maxAttempts; attempt++ {
+		if attempt > 0 {
+			delay := backoffDelay(policy.baseDelay, attempt, resp)
+			if delay > 0 {
+				timer := time.NewTimer(delay)
+				select {
+				case <-req.Context().Done():
+					timer.Stop()
+					return nil, req.Context().Err()
+				case <-timer.C:
+				}
+			}
+		}
+		if req.GetBody != nil {
+			body, berr := req.GetBody()
+			if berr != nil {
+				return nil, berr
+			}
+			req.Body = body
+		}
+
+		req.Header.Set("Client-ID", t.client.ID)
+		if bearer := t.client.currentBearer(); len(bearer) > 0 {
+			req.Header.Set("Authorization", "OAuth "+bearer)
+		}
+
+		resp, err = t.next.RoundTrip(req)
+		if err != nil {
+			continue
+		}
+
+		if resp.StatusCode == http.StatusUnauthorized && !refreshedAuth && t.client.tokenSource != nil {
+			refreshedAuth = true
+			resp.Body.Close()
+			token, terr := t.client.tokenSource.Token(req.Context())
+			if terr != nil {
+				return resp, terr
+			}
+			t.client.setBearer(token)
+			attempt--
+			continue
+		}
+
+		if !isTransientStatus(resp.StatusCode) || attempt == policy.maxAttempts-1 {
+			return resp, nil
+		}
+		resp.Body.Close()
+	}
+	return resp, err
+}
+
+// isTransientStatus reports whether a response status code represents a
+// transient failure worth retrying.
+func isTransientStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// backoffDelay computes the delay before the next attempt, honoring a
+// Retry-After header on the previous response when present and otherwise
+// falling back to exponential backoff with jitter.
+func backoffDelay(base time.Duration, attempt int, prev *http.Response) time.Duration {
+	if prev != nil {
+		if retryAfter := prev.Header.Get("Retry-After"); len(retryAfter) > 0 {
+			if seconds, err := strconv.Atoi(retryAfter); err == nil {
+				return time.Duration(seconds) * time.Second
+			}
+		}
+	}
+	if base <= 0 {
+		return 0
+	}
+	delay := base << uint(attempt-1)
+	jitter := time.Duration(rand.Int63n(int64(base)))
+	return delay + jitter
+}
@@ -0,0 +1,133 @@
+package graphql
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHttpTransportRetriesTransientStatus(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := New()
+	client.SetRetryPolicy(5, time.Millisecond)
+	transport := httpTransport{client: client, next: http.DefaultTransport}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("unexpected error building request: %v", err)
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected final status 200, got %d", resp.StatusCode)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Fatalf("expected 3 attempts, got %d", got)
+	}
+}
+
+func TestHttpTransportDoesNotRetryWithoutPolicy(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := New()
+	transport := httpTransport{client: client, next: http.DefaultTransport}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("unexpected error building request: %v", err)
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Fatalf("expected exactly 1 attempt with no retry policy configured, got %d", got)
+	}
+}
+
+func TestHttpTransportStopsRetryingOnContextCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := New()
+	client.SetRetryPolicy(10, 50*time.Millisecond)
+	transport := httpTransport{client: client, next: http.DefaultTransport}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("unexpected error building request: %v", err)
+	}
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	_, err = transport.RoundTrip(req)
+	if err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestIsTransientStatus(t *testing.T) {
+	cases := map[int]bool{
+		http.StatusOK:                 false,
+		http.StatusUnauthorized:       false,
+		http.StatusTooManyRequests:    true,
+		http.StatusInternalServerError: true,
+		http.StatusBadGateway:         true,
+	}
+	for status, want := range cases {
+		if got := isTransientStatus(status); got != want {
+			t.Errorf("isTransientStatus(%d) = %v, want %v", status, got, want)
+		}
+	}
+}
+
+func TestBackoffDelayHonorsRetryAfter(t *testing.T) {
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"2"}}}
+	delay := backoffDelay(100*time.Millisecond, 1, resp)
+	if delay != 2*time.Second {
+		t.Fatalf("expected 2s delay from Retry-After, got %v", delay)
+	}
+}
+
+func TestBackoffDelayExponential(t *testing.T) {
+	base := 10 * time.Millisecond
+	first := backoffDelay(base, 1, nil)
+	second := backoffDelay(base, 2, nil)
+	if first < base {
+		t.Fatalf("expected first backoff >= base delay, got %v", first)
+	}
+	if second < 2*base {
+		t.Fatalf("expected second backoff to grow exponentially, got %v", second)
+	}
+}